@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// SQLBulkInsertStmt generate statement for INSERT of n rows in one round
+// trip: "INSERT INTO t (cols) VALUES (?,?,...),(?,?,...)" with n groups.
+func (fds *_FieldsMap) SQLBulkInsertStmt(ctx context.Context, tx *sql.Tx, db *sql.DB, n int) (*sql.Stmt, error) {
+
+	if n <= 0 {
+		return nil, errors.New("mysql: SQLBulkInsertStmt: n must be > 0")
+	}
+
+	group := "(" + placeholders(len(fds.fields)) + ")"
+
+	var groups string
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			groups += ", "
+		}
+		groups += group
+	}
+
+	sqlstr := "INSERT INTO `" + fds.table + "` (" + fds.SQLFieldsStr() + ") " +
+		"VALUES " + groups
+	return fds.PrepareStmt(ctx, tx, db, sqlstr)
+}
+
+// ExecBulk executes a SQLBulkInsertStmt statement, flattening the field
+// values of objptrs (each the same shape as this FieldsMap) into its args
+func (fds *_FieldsMap) ExecBulk(ctx context.Context, stmt *sql.Stmt, objptrs []interface{}) (sql.Result, error) {
+
+	var args []interface{}
+	for _, objptr := range objptrs {
+		fieldsMap, err := NewFieldsMap(fds.table, objptr)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, fieldsMap.GetFieldValues()...)
+	}
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+// SQLUpsertStmt generate statement for INSERT ... ON DUPLICATE KEY UPDATE,
+// updating every column not in keyCols
+func (fds *_FieldsMap) SQLUpsertStmt(ctx context.Context, tx *sql.Tx, db *sql.DB, keyCols []string) (*sql.Stmt, error) {
+
+	keys := make(map[string]bool, len(keyCols))
+	for _, k := range keyCols {
+		keys[k] = true
+	}
+
+	var updates string
+	for i, flen := 0, len(fds.fields); i < flen; i++ {
+		if keys[fds.fields[i].Tag] {
+			continue
+		}
+		if len(updates) > 0 {
+			updates += ", "
+		}
+		updates += "`" + fds.fields[i].Tag + "` = VALUES(`" + fds.fields[i].Tag + "`)"
+	}
+
+	if len(updates) == 0 {
+		return nil, errors.New("mysql: SQLUpsertStmt: no columns left to update")
+	}
+
+	sqlstr := "INSERT INTO `" + fds.table + "` (" + fds.SQLFieldsStr() + ") " +
+		"VALUES (" + placeholders(len(fds.fields)) + ") " +
+		"ON DUPLICATE KEY UPDATE " + updates
+	return fds.PrepareStmt(ctx, tx, db, sqlstr)
+}
+
+// placeholders returns n comma-separated "?" marks
+func placeholders(n int) string {
+
+	var vs string
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			vs += ", "
+		}
+		vs += "?"
+	}
+	return vs
+}
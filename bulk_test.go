@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestSQLBulkInsertStmt(t *testing.T) {
+
+	fieldsMap, err := NewFieldsMap(table, &DemoRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if _, err := fieldsMap.SQLBulkInsertStmt(ctx, nil, nil, 0); err == nil {
+		t.Error("expected error for n <= 0")
+	}
+
+	db, err := sql.Open("mysql", "root:123456@/testdb")
+	if err != nil {
+		t.Log(err.Error())
+		return
+	}
+
+	_, err = fieldsMap.SQLBulkInsertStmt(ctx, nil, db, 3)
+	if err != nil {
+		t.Log(err.Error())
+		return
+	}
+}
+
+func TestSQLUpsertStmt(t *testing.T) {
+
+	fieldsMap, err := NewFieldsMap(table, &DemoRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if _, err := fieldsMap.SQLUpsertStmt(ctx, nil, nil, []string{
+		"field_key", "field_one", "field_two", "field_thr", "field_fou"}); err == nil {
+		t.Error("expected error when every column is a key")
+	}
+
+	db, err := sql.Open("mysql", "root:123456@/testdb")
+	if err != nil {
+		t.Log(err.Error())
+		return
+	}
+
+	_, err = fieldsMap.SQLUpsertStmt(ctx, nil, db, []string{"field_key"})
+	if err != nil {
+		t.Log(err.Error())
+		return
+	}
+}
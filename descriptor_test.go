@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescriptorsForCached(t *testing.T) {
+
+	reftype := reflect.TypeOf(DemoRow{})
+
+	a := descriptorsFor(reftype)
+	b := descriptorsFor(reftype)
+
+	if &a[0] != &b[0] {
+		t.Error("descriptorsFor should return the same cached slice for the same type")
+	}
+}
+
+// EmbeddedRow embeds Base's tagged fields alongside its own
+type Base struct {
+	FieldKey string `sql:"field_key"`
+}
+
+type EmbeddedRow struct {
+	Base
+	FieldOne string `sql:"field_one"`
+}
+
+func TestNewFieldsMapEmbedded(t *testing.T) {
+
+	obj := EmbeddedRow{
+		Base:     Base{FieldKey: "key"},
+		FieldOne: "one",
+	}
+
+	fieldsMap, err := NewFieldsMap("embedded_table", &obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := fieldsMap.GetFieldNamesInDB()
+	want := []string{"field_key", "field_one"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+
+	values := fieldsMap.GetFieldValues()
+	if values[0] != "key" || values[1] != "one" {
+		t.Errorf("values = %v", values)
+	}
+}
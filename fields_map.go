@@ -3,8 +3,12 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // Field db field
@@ -17,16 +21,43 @@ import (
 // 	FieldFou float64 `sql:"field_fou"`
 // }
 //
+// time.Time, []byte, sql.NullString/NullInt64/NullFloat64/NullBool/NullTime
+// and any type implementing driver.Valuer+sql.Scanner are also supported,
+// see direct below.
+//
+// The `sql:"..."` tag may carry schema options after the column name, comma
+// separated, consumed by Schema (see schema.go):
+// `sql:"field_key,pk,size:64,notnull,default:'x',index"`.
 type Field struct {
 	Name        string
 	Tag         string
 	Type        string
 	IntValue    int64
+	UintValue   uint64 // uint/uint8/.../uint64, kept separate from IntValue so large uint64 values don't wrap
 	StringValue string
 	FloatValue  float64
 	BoolValue   bool
+	Value       interface{} // snapshot used for direct fields, see direct below
 	Addr        interface{}
 	Save        []byte // for null string
+
+	// direct is true for fields that scan/value themselves (time.Time,
+	// []byte, sql.Null*, driver.Valuer+sql.Scanner): GetFieldAddrs returns
+	// Addr as-is and MappingBackToObject does not need to copy anything back.
+	direct bool
+
+	// kind drives GetFieldAddrs/MappingBackToObject: which of the scratch
+	// fields above (IntValue, UintValue, ...) a scalar field round-trips
+	// Scan args through. GetFieldValues picks the same scratch fields by Type.
+	kind fieldKind
+
+	// schema options parsed from the tag, used by Schema's DDL generation
+	PK         bool
+	Size       int
+	NotNull    bool
+	Default    string
+	HasDefault bool
+	Index      bool
 }
 
 // FieldsMap hold Field
@@ -75,43 +106,229 @@ type FieldsMap interface {
 	// SQLDeleteStmt generate statement for DELETE
 	SQLDeleteStmt(ctx context.Context, tx *sql.Tx, db *sql.DB,
 		extStr string) (*sql.Stmt, error)
+
+	////////////////////////////////////////////////////////////////
+	// bulk statements, see bulk.go
+
+	// SQLBulkInsertStmt generate statement for INSERT of n rows in one round trip
+	SQLBulkInsertStmt(ctx context.Context, tx *sql.Tx, db *sql.DB, n int) (*sql.Stmt, error)
+
+	// ExecBulk executes a SQLBulkInsertStmt statement, flattening the field
+	// values of objptrs (each the same shape as this FieldsMap) into its args
+	ExecBulk(ctx context.Context, stmt *sql.Stmt, objptrs []interface{}) (sql.Result, error)
+
+	// SQLUpsertStmt generate statement for INSERT ... ON DUPLICATE KEY UPDATE,
+	// updating every column not in keyCols
+	SQLUpsertStmt(ctx context.Context, tx *sql.Tx, db *sql.DB, keyCols []string) (*sql.Stmt, error)
+
+	////////////////////////////////////////////////////////////////
+	// fluent query builder
+
+	// QuerySet starts a chainable QuerySet bound to this FieldsMap's table
+	QuerySet(ctx context.Context, db *sql.DB) QuerySet
 }
 
 ////////////////////////////////////////////////////////////////
+// reflection cache
+//
+// NewFieldsMap used to walk every struct field with reflection, including
+// re-reading tags and switching on field.Type.String(), on every single
+// call - once per row in a scan loop. fieldKind/fieldDescriptor capture
+// everything that only depends on reflect.Type (name, tag, schema options,
+// how to populate a Field), computed once per struct type and cached in
+// descriptorCache. NewFieldsMap then just looks the descriptors up and
+// binds them to the new objptr.
+
+// fieldKind says how a descriptor's Field should be populated/valued
+type fieldKind int
+
+const (
+	kindInt fieldKind = iota
+	kindUint
+	kindString
+	kindFloat
+	kindBool
+	kindDirect // time.Time, []byte, sql.Null*, driver.Valuer+sql.Scanner
+)
 
-// NewFieldsMap new Fields
-func NewFieldsMap(table string, objptr interface{}) (FieldsMap, error) {
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
 
-	elem := reflect.ValueOf(objptr).Elem()
-	reftype := elem.Type()
+// fieldDescriptor is the reflect.Type-only description of one struct field
+type fieldDescriptor struct {
+	name  string
+	tag   string
+	typ   string
+	index []int // reflect.StructField.Index, supports embedded fields
+	kind  fieldKind
+	err   error // set when typ is not a supported field type
+
+	pk         bool
+	size       int
+	notNull    bool
+	def        string
+	hasDefault bool
+	dbIndex    bool
+}
+
+var descriptorCache sync.Map // reflect.Type -> []fieldDescriptor
 
-	var fields []Field
+// descriptorsFor returns reftype's field descriptors, computing and caching
+// them on the first call for that type.
+func descriptorsFor(reftype reflect.Type) []fieldDescriptor {
+
+	if cached, ok := descriptorCache.Load(reftype); ok {
+		return cached.([]fieldDescriptor)
+	}
+
+	descs := walkFields(reftype, nil)
+
+	actual, _ := descriptorCache.LoadOrStore(reftype, descs)
+	return actual.([]fieldDescriptor)
+}
+
+// walkFields builds descriptors for reftype's fields, recursing into
+// anonymous embedded structs (but not time.Time or Scanner-capable ones like
+// sql.NullString) so embedded fields are mapped like the rest of the repo's
+// tagged fields.
+func walkFields(reftype reflect.Type, outer []int) []fieldDescriptor {
+
+	var descs []fieldDescriptor
 	for i, flen := 0, reftype.NumField(); i < flen; i++ {
 
-		var field Field
-		field.Name = reftype.Field(i).Name
-		field.Tag = reftype.Field(i).Tag.Get("sql")
-		field.Type = reftype.Field(i).Type.String()
-		field.Addr = elem.Field(i).Addr().Interface()
-
-		switch field.Type {
-		case "int64":
-			field.IntValue = elem.Field(i).Int()
-			break
+		sf := reftype.Field(i)
+		index := appendIndex(outer, i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && !isDirectType(sf.Type) &&
+			sf.Type.String() != "time.Time" {
+			descs = append(descs, walkFields(sf.Type, index)...)
+			continue
+		}
+
+		var d fieldDescriptor
+		d.name = sf.Name
+		d.typ = sf.Type.String()
+		d.index = index
+		parseSQLTag(&d, sf.Tag.Get("sql"))
+
+		switch d.typ {
+		case "int64", "int", "int8", "int16", "int32":
+			d.kind = kindInt
+		case "uint", "uint8", "uint16", "uint32", "uint64":
+			d.kind = kindUint
 		case "string":
-			field.StringValue = elem.Field(i).String()
-			break
-		case "float64":
-			field.FloatValue = elem.Field(i).Float()
-			break
+			d.kind = kindString
+		case "float64", "float32":
+			d.kind = kindFloat
 		case "bool":
-			field.BoolValue = elem.Field(i).Bool()
-			break
+			d.kind = kindBool
+		case "time.Time", "[]uint8":
+			d.kind = kindDirect
 		default:
-			return nil, errors.New("unsupported field.Type:" + field.Type)
+			if isDirectType(sf.Type) {
+				d.kind = kindDirect
+			} else {
+				d.err = errors.New("unsupported field.Type:" + d.typ)
+			}
 		}
 
-		fields = append(fields, field)
+		descs = append(descs, d)
+	}
+
+	return descs
+}
+
+func appendIndex(outer []int, i int) []int {
+
+	index := make([]int, len(outer)+1)
+	copy(index, outer)
+	index[len(outer)] = i
+	return index
+}
+
+// isDirectType reports whether a field of type t can be scanned and valued
+// through its own Addr, like sql.NullString and sqlx's Scanner-capable
+// fields do, rather than through one of the Field.*Value snapshots.
+func isDirectType(t reflect.Type) bool {
+
+	return reflect.PtrTo(t).Implements(scannerType) && t.Implements(valuerType)
+}
+
+// parseSQLTag splits a `sql:"..."` tag into its column name and schema
+// options. A tag with no options, e.g. `sql:"field_key"`, just sets d.tag.
+func parseSQLTag(d *fieldDescriptor, tag string) {
+
+	parts := strings.Split(tag, ",")
+	d.tag = strings.TrimSpace(parts[0])
+
+	for _, opt := range parts[1:] {
+		opt = strings.TrimSpace(opt)
+		switch {
+		case opt == "pk":
+			d.pk = true
+		case opt == "notnull":
+			d.notNull = true
+		case opt == "index":
+			d.dbIndex = true
+		case strings.HasPrefix(opt, "size:"):
+			d.size, _ = strconv.Atoi(strings.TrimPrefix(opt, "size:"))
+		case strings.HasPrefix(opt, "default:"):
+			d.def = strings.Trim(strings.TrimPrefix(opt, "default:"), "'")
+			d.hasDefault = true
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////
+
+// NewFieldsMap new Fields
+func NewFieldsMap(table string, objptr interface{}) (FieldsMap, error) {
+
+	elem := reflect.ValueOf(objptr).Elem()
+	descs := descriptorsFor(elem.Type())
+
+	fields := make([]Field, len(descs))
+	for i := range descs {
+		d := &descs[i]
+		if d.err != nil {
+			return nil, d.err
+		}
+
+		fv := elem.FieldByIndex(d.index)
+
+		var field Field
+		field.Name = d.name
+		field.Tag = d.tag
+		field.Type = d.typ
+		field.kind = d.kind
+		field.PK = d.pk
+		field.Size = d.size
+		field.NotNull = d.notNull
+		field.Default = d.def
+		field.HasDefault = d.hasDefault
+		field.Index = d.dbIndex
+		field.Addr = fv.Addr().Interface()
+
+		switch d.kind {
+		case kindInt:
+			field.IntValue = fv.Int()
+		case kindUint:
+			field.UintValue = fv.Uint()
+		case kindString:
+			field.StringValue = fv.String()
+		case kindFloat:
+			field.FloatValue = fv.Float()
+		case kindBool:
+			field.BoolValue = fv.Bool()
+		case kindDirect:
+			// scanned/valued directly by database/sql, no snapshot needed
+			field.Value = fv.Interface()
+			field.direct = true
+		}
+
+		fields[i] = field
 	}
 
 	return &_FieldsMap{
@@ -165,19 +382,26 @@ func (fds *_FieldsMap) GetFieldValues() []interface{} {
 	var values []interface{}
 	for i, flen := 0, len(fds.fields); i < flen; i++ {
 		switch fds.fields[i].Type {
-		case "int64":
+		case "int64", "int", "int8", "int16", "int32":
 			values = append(values, fds.fields[i].IntValue)
 			break
+		case "uint", "uint8", "uint16", "uint32", "uint64":
+			values = append(values, fds.fields[i].UintValue)
+			break
 		case "string":
 			values = append(values, fds.fields[i].StringValue)
 			break
-		case "float64":
+		case "float64", "float32":
 			values = append(values, fds.fields[i].FloatValue)
 			break
 		case "bool":
 			values = append(values, fds.fields[i].BoolValue)
 			break
 		default:
+			if fds.fields[i].direct {
+				values = append(values, fds.fields[i].Value)
+				break
+			}
 			values = append(values, nil)
 			break
 		}
@@ -187,15 +411,33 @@ func (fds *_FieldsMap) GetFieldValues() []interface{} {
 }
 
 // GetFieldAddrs get Pointers of Values in Object(struct)
+//
+// Every kind except direct scans into one of Field's own scratch fields
+// (Save/IntValue/UintValue/FloatValue/BoolValue), never into &Field.Addr:
+// Addr holds an interface{} wrapping a pointer into the original struct,
+// and database/sql's *interface{} destination case simply overwrites that
+// box with the raw driver value instead of writing through it, silently
+// discarding the original pointer. MappingBackToObject copies each scratch
+// value back into the original object afterwards.
 func (fds *_FieldsMap) GetFieldAddrs() []interface{} {
 
 	var addrs []interface{}
 	for i, flen := 0, len(fds.fields); i < flen; i++ {
-		if fds.fields[i].Type == "string" {
+		switch fds.fields[i].kind {
+		case kindString:
 			// "string" need bytes => string for empty string
 			addrs = append(addrs, &fds.fields[i].Save)
-		} else {
-			addrs = append(addrs, &fds.fields[i].Addr)
+		case kindInt:
+			addrs = append(addrs, &fds.fields[i].IntValue)
+		case kindUint:
+			addrs = append(addrs, &fds.fields[i].UintValue)
+		case kindFloat:
+			addrs = append(addrs, &fds.fields[i].FloatValue)
+		case kindBool:
+			addrs = append(addrs, &fds.fields[i].BoolValue)
+		case kindDirect:
+			// Scanner-capable fields scan straight into the original object
+			addrs = append(addrs, fds.fields[i].Addr)
 		}
 	}
 
@@ -206,10 +448,19 @@ func (fds *_FieldsMap) GetFieldAddrs() []interface{} {
 func (fds *_FieldsMap) MappingBackToObject() interface{} {
 
 	for i, flen := 0, len(fds.fields); i < flen; i++ {
-		switch fds.fields[i].Type {
-		case "string":
+		switch fds.fields[i].kind {
+		case kindString:
 			*fds.fields[i].Addr.(*string) = string(fds.fields[i].Save)
-			break
+		case kindInt:
+			reflect.ValueOf(fds.fields[i].Addr).Elem().SetInt(fds.fields[i].IntValue)
+		case kindUint:
+			reflect.ValueOf(fds.fields[i].Addr).Elem().SetUint(fds.fields[i].UintValue)
+		case kindFloat:
+			reflect.ValueOf(fds.fields[i].Addr).Elem().SetFloat(fds.fields[i].FloatValue)
+		case kindBool:
+			reflect.ValueOf(fds.fields[i].Addr).Elem().SetBool(fds.fields[i].BoolValue)
+		case kindDirect:
+			// already scanned directly into the original object, nothing to do
 		}
 	}
 
@@ -321,3 +572,16 @@ func (fds *_FieldsMap) SQLDeleteStmt(ctx context.Context, tx *sql.Tx, db *sql.DB
 	sqlstr := "DELETE FROM `" + fds.table + "` " + extStr
 	return fds.PrepareStmt(ctx, tx, db, sqlstr)
 }
+
+////////////////////////////////////////////////////////////////
+// fluent query builder
+
+// QuerySet starts a chainable QuerySet bound to this FieldsMap's table
+func (fds *_FieldsMap) QuerySet(ctx context.Context, db *sql.DB) QuerySet {
+
+	return &_QuerySet{
+		fds: fds,
+		ctx: ctx,
+		db:  db,
+	}
+}
@@ -3,7 +3,9 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"math"
 	"testing"
+	"time"
 )
 
 // DemoRow mapping row of demo_table in db
@@ -17,6 +19,46 @@ type DemoRow struct {
 
 var table = "demo_table"
 
+// NullableRow mapping row of a table with NULL-able and extended Go types
+type NullableRow struct {
+	FieldKey  string         `sql:"field_key"`
+	FieldInt  int32          `sql:"field_int"`
+	FieldName sql.NullString `sql:"field_name"`
+	FieldAge  sql.NullInt64  `sql:"field_age"`
+	FieldAt   time.Time      `sql:"field_at"`
+	FieldRaw  []byte         `sql:"field_raw"`
+}
+
+func TestFieldsMapNullable(t *testing.T) {
+
+	obj := NullableRow{
+		FieldKey:  "field key",
+		FieldInt:  7,
+		FieldName: sql.NullString{String: "name", Valid: true},
+		FieldAge:  sql.NullInt64{Valid: false},
+		FieldAt:   time.Now(),
+		FieldRaw:  []byte("raw"),
+	}
+
+	fieldsMap, err := NewFieldsMap("nullable_table", &obj)
+	if err != nil {
+		t.Error(err)
+	}
+
+	values := fieldsMap.GetFieldValues()
+	if len(values) != len(fieldsMap.GetFields()) {
+		t.Error("values count mismatch fields count")
+	}
+
+	addrs := fieldsMap.GetFieldAddrs()
+	t.Log(addrs)
+
+	row := *fieldsMap.MappingBackToObject().(*NullableRow)
+	if row.FieldName.String != "name" {
+		t.Error("MappingBackToObject lost FieldName")
+	}
+}
+
 func TestFieldsMap(t *testing.T) {
 
 	obj := DemoRow{
@@ -151,34 +193,37 @@ func testSelectRow(ctx context.Context, db *sql.DB, t *testing.T, fieldsMap Fiel
 func testSelectRows(ctx context.Context, db *sql.DB, t *testing.T, fieldsMap FieldsMap) {
 
 	fields := fieldsMap.GetFields()
-	extStr := " where `" + fields[0].Tag + "` = ? "
-	stmt, err := fieldsMap.SQLSelectStmt(ctx, nil, db, extStr)
-	if err != nil {
+	sqlstr := "select " + fieldsMap.SQLFieldsStr() + " from " + table +
+		" where `" + fields[0].Tag + "` = ? "
+
+	var demoRows []DemoRow
+	if err := Select(ctx, db, &demoRows, sqlstr, fields[0]); err != nil {
 		t.Log(err.Error())
 		return
 	}
-	rs, err := stmt.QueryContext(ctx, fields[0])
+}
+
+// UintRow mapping row of a table with a large uint64 column
+type UintRow struct {
+	FieldKey string `sql:"field_key"`
+	FieldBig uint64 `sql:"field_big"`
+}
+
+func TestGetFieldValuesUint64NoOverflow(t *testing.T) {
+
+	obj := UintRow{
+		FieldKey: "key",
+		FieldBig: math.MaxUint64,
+	}
+
+	fieldsMap, err := NewFieldsMap("uint_table", &obj)
 	if err != nil {
-		t.Log(err.Error())
-		return
+		t.Fatal(err)
 	}
 
-	var demoRows []DemoRow
-	for rs.Next() {
-		var demoRow DemoRow
-		fieldsMap, err := NewFieldsMap(table, &demoRow)
-		if err != nil {
-			t.Log(err.Error())
-			return
-		}
-
-		err = rs.Scan(fieldsMap.GetFieldAddrs()...)
-		if err != nil {
-			t.Log(err.Error())
-			return
-		}
-
-		demoRow = *fieldsMap.MappingBackToObject().(*DemoRow)
-		demoRows = append(demoRows, demoRow)
+	values := fieldsMap.GetFieldValues()
+	got, ok := values[1].(uint64)
+	if !ok || got != uint64(math.MaxUint64) {
+		t.Errorf("values[1] = %#v, want uint64(math.MaxUint64)", values[1])
 	}
 }
@@ -0,0 +1,205 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Named rewrites sqlstr's named placeholders (":field_one") into positional
+// "?" placeholders and returns them together with the ordered argument slice
+// pulled from arg. arg may be a pointer to a struct tagged with `sql:"..."`
+// (matched through FieldsMap) or a map[string]interface{} keyed by the
+// placeholder name. "::" is left untouched so MySQL cast syntax still works,
+// and ":" inside quoted/backtick-quoted text is never treated as a
+// placeholder.
+//
+// example:
+//	sqlstr, args, err := mysql.Named(
+//		"UPDATE demo_table SET field_one=:field_one WHERE field_key=:field_key",
+//		&obj)
+func Named(sqlstr string, arg interface{}) (string, []interface{}, error) {
+
+	names, query := parseNamed(sqlstr)
+
+	values, err := namedValues(arg, names)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, values, nil
+}
+
+// parseNamed walks sqlstr once, replacing each ":name" occurrence with "?"
+// and collecting the names in order, skipping quoted text and "::" casts.
+func parseNamed(sqlstr string) ([]string, string) {
+
+	var names []string
+	var out strings.Builder
+
+	inSingle, inDouble, inBacktick := false, false, false
+
+	runes := []rune(sqlstr)
+	n := len(runes)
+	for i := 0; i < n; i++ {
+		c := runes[i]
+
+		if inSingle || inDouble || inBacktick {
+			out.WriteRune(c)
+			switch {
+			case inSingle && c == '\'':
+				inSingle = false
+			case inDouble && c == '"':
+				inDouble = false
+			case inBacktick && c == '`':
+				inBacktick = false
+			}
+			continue
+		}
+
+		switch c {
+		case '\'':
+			inSingle = true
+			out.WriteRune(c)
+			continue
+		case '"':
+			inDouble = true
+			out.WriteRune(c)
+			continue
+		case '`':
+			inBacktick = true
+			out.WriteRune(c)
+			continue
+		}
+
+		if c == ':' {
+			if i+1 < n && runes[i+1] == ':' {
+				out.WriteString("::")
+				i++
+				continue
+			}
+
+			j := i + 1
+			for j < n && isNameRune(runes[j]) {
+				j++
+			}
+			if j > i+1 {
+				names = append(names, string(runes[i+1:j]))
+				out.WriteRune('?')
+				i = j - 1
+				continue
+			}
+		}
+
+		out.WriteRune(c)
+	}
+
+	return names, out.String()
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// namedValues resolves names in order against arg, a struct pointer (via
+// FieldsMap's `sql:"..."` tags) or a map[string]interface{}.
+func namedValues(arg interface{}, names []string) ([]interface{}, error) {
+
+	if m, ok := arg.(map[string]interface{}); ok {
+		values := make([]interface{}, len(names))
+		for i, name := range names {
+			v, ok := m[name]
+			if !ok {
+				return nil, errors.New("mysql: Named: missing key :" + name)
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+
+	fieldsMap, err := NewFieldsMap("", arg)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := fieldsMap.GetFields()
+	fieldValues := fieldsMap.GetFieldValues()
+
+	byTag := make(map[string]interface{}, len(fields))
+	for i, field := range fields {
+		byTag[field.Tag] = fieldValues[i]
+	}
+
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		v, ok := byTag[name]
+		if !ok {
+			return nil, errors.New("mysql: Named: missing field for :" + name)
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+////////////////////////////////////////////////////////////////
+
+// StructScan scans the current row of rows into dest, a pointer to a struct
+// tagged with `sql:"..."`. The caller is still responsible for calling
+// rows.Next() before StructScan and rows.Close() when done.
+func StructScan(rows *sql.Rows, dest interface{}) error {
+
+	fieldsMap, err := NewFieldsMap("", dest)
+	if err != nil {
+		return err
+	}
+
+	if err := rows.Scan(fieldsMap.GetFieldAddrs()...); err != nil {
+		return err
+	}
+
+	fieldsMap.MappingBackToObject()
+	return nil
+}
+
+// Select runs sqlstr against db with args and scans every row into dest, a
+// pointer to a slice of structs tagged with `sql:"..."`. It replaces the
+// NewFieldsMap+Scan+MappingBackToObject loop callers previously had to
+// open-code per row.
+func Select(ctx context.Context, db *sql.DB, dest interface{}, sqlstr string, args ...interface{}) error {
+
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanRowsInto(rows, dest)
+}
+
+// scanRowsInto scans every remaining row of rows into dest, a pointer to a
+// slice of structs tagged with `sql:"..."`. The caller owns closing rows.
+func scanRowsInto(rows *sql.Rows, dest interface{}) error {
+
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.Elem().Kind() != reflect.Slice {
+		return errors.New("mysql: dest must be a pointer to a slice")
+	}
+	sliceVal := destVal.Elem()
+	elemType := sliceVal.Type().Elem()
+
+	for rows.Next() {
+		rowPtr := reflect.New(elemType)
+
+		if err := StructScan(rows, rowPtr.Interface()); err != nil {
+			return err
+		}
+
+		sliceVal.Set(reflect.Append(sliceVal, rowPtr.Elem()))
+	}
+
+	return rows.Err()
+}
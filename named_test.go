@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamed(t *testing.T) {
+
+	obj := DemoRow{
+		FieldKey: "field key",
+		FieldOne: "field one",
+		FieldTwo: true,
+		FieldThr: 123,
+		FieldFou: 123.45,
+	}
+
+	sqlstr, args, err := Named(
+		"UPDATE demo_table SET field_one=:field_one WHERE field_key=:field_key",
+		&obj)
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantSQL := "UPDATE demo_table SET field_one=? WHERE field_key=?"
+	if sqlstr != wantSQL {
+		t.Errorf("sqlstr = %q, want %q", sqlstr, wantSQL)
+	}
+
+	wantArgs := []interface{}{"field one", "field key"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestNamedQuotedAndCast(t *testing.T) {
+
+	names, query := parseNamed(
+		"SELECT * FROM demo_table WHERE field_one = ':not_a_param' AND field_two = :field_two::int")
+
+	want := "SELECT * FROM demo_table WHERE field_one = ':not_a_param' AND field_two = ?::int"
+	if query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	if len(names) != 1 || names[0] != "field_two" {
+		t.Errorf("names = %v, want [field_two]", names)
+	}
+}
+
+func TestNamedFromMap(t *testing.T) {
+
+	sqlstr, args, err := Named(
+		"UPDATE demo_table SET field_one=:field_one WHERE field_key=:field_key",
+		map[string]interface{}{"field_one": "one", "field_key": "key"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	wantSQL := "UPDATE demo_table SET field_one=? WHERE field_key=?"
+	if sqlstr != wantSQL {
+		t.Errorf("sqlstr = %q, want %q", sqlstr, wantSQL)
+	}
+
+	wantArgs := []interface{}{"one", "key"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
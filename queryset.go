@@ -0,0 +1,417 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// QuerySet is a chainable query builder inspired by beego/orm's condition
+// system: chain Filter/Exclude/OrderBy/GroupBy/Limit/Offset, then run a
+// terminal All/One/Update/Delete. Column names are validated against the
+// bound FieldsMap's `sql:"..."` tags, so callers never hand-write extStr
+// fragments or interpolate untrusted column names into SQL.
+//
+// colOp arguments to Filter/Exclude take the form "column" or
+// "column__op", where op is one of: exact, iexact, contains, icontains,
+// startswith, endswith, gt, gte, lt, lte, in, between, isnull. The default
+// op, when none is given, is exact.
+type QuerySet interface {
+
+	// Filter adds an AND'ed condition
+	Filter(colOp string, value interface{}) QuerySet
+
+	// Exclude adds an AND NOT'ed condition
+	Exclude(colOp string, value interface{}) QuerySet
+
+	// OrderBy sets the ORDER BY columns, prefix a column with "-" for DESC
+	OrderBy(cols ...string) QuerySet
+
+	// GroupBy sets the GROUP BY columns
+	GroupBy(cols ...string) QuerySet
+
+	// Limit sets the LIMIT clause
+	Limit(n int) QuerySet
+
+	// Offset sets the OFFSET clause
+	Offset(n int) QuerySet
+
+	// All runs SELECT and scans every matching row into dest, a pointer to a slice of structs
+	All(dest interface{}) error
+
+	// One runs SELECT and scans the first matching row into dest, a struct pointer
+	One(dest interface{}) error
+
+	// Update runs UPDATE setting values, keyed by `sql:"..."` tag, on every matching row
+	Update(values map[string]interface{}) (sql.Result, error)
+
+	// Delete runs DELETE on every matching row
+	Delete() (sql.Result, error)
+}
+
+var _ QuerySet = &_QuerySet{}
+
+type _QuerySet struct {
+	fds *_FieldsMap
+	ctx context.Context
+	db  *sql.DB
+
+	conds   []qsCond
+	orderBy []string
+	groupBy []string
+	limit   int
+	offset  int
+	hasLim  bool
+	hasOff  bool
+	err     error
+}
+
+type qsCond struct {
+	sqlstr string
+	args   []interface{}
+}
+
+// qsColumns is the set of columns the bound FieldsMap is allowed to filter,
+// order or group by.
+func (qs *_QuerySet) qsColumns() map[string]bool {
+
+	cols := make(map[string]bool, len(qs.fds.fields))
+	for i := range qs.fds.fields {
+		cols[qs.fds.fields[i].Tag] = true
+	}
+	return cols
+}
+
+func (qs *_QuerySet) addCond(colOp string, value interface{}, negate bool) QuerySet {
+
+	if qs.err != nil {
+		return qs
+	}
+
+	col, op, found := strings.Cut(colOp, "__")
+	if !found {
+		op = "exact"
+	}
+
+	if !qs.qsColumns()[col] {
+		qs.err = errors.New("mysql: QuerySet: unknown column " + col)
+		return qs
+	}
+
+	sqlstr, args, err := qsOperator(col, op, value)
+	if err != nil {
+		qs.err = err
+		return qs
+	}
+
+	if negate {
+		sqlstr = "NOT (" + sqlstr + ")"
+	}
+
+	qs.conds = append(qs.conds, qsCond{sqlstr: sqlstr, args: args})
+	return qs
+}
+
+func qsOperator(col, op string, value interface{}) (string, []interface{}, error) {
+
+	quoted := "`" + col + "`"
+
+	switch op {
+	case "exact":
+		return quoted + " = ?", []interface{}{value}, nil
+	case "iexact":
+		s, err := likeValue(op, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return quoted + " LIKE ?", []interface{}{s}, nil
+	case "contains":
+		s, err := likeValue(op, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return quoted + " LIKE ?", []interface{}{"%" + s + "%"}, nil
+	case "icontains":
+		s, err := likeValue(op, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return quoted + " LIKE ?", []interface{}{"%" + s + "%"}, nil
+	case "startswith":
+		s, err := likeValue(op, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return quoted + " LIKE ?", []interface{}{s + "%"}, nil
+	case "endswith":
+		s, err := likeValue(op, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return quoted + " LIKE ?", []interface{}{"%" + s}, nil
+	case "gt":
+		return quoted + " > ?", []interface{}{value}, nil
+	case "gte":
+		return quoted + " >= ?", []interface{}{value}, nil
+	case "lt":
+		return quoted + " < ?", []interface{}{value}, nil
+	case "lte":
+		return quoted + " <= ?", []interface{}{value}, nil
+	case "in":
+		values, ok := toSlice(value)
+		if !ok || len(values) == 0 {
+			return "", nil, errors.New("mysql: QuerySet: __in needs a non-empty slice")
+		}
+		marks := strings.Repeat("?,", len(values))
+		marks = marks[:len(marks)-1]
+		return quoted + " IN (" + marks + ")", values, nil
+	case "between":
+		values, ok := toSlice(value)
+		if !ok || len(values) != 2 {
+			return "", nil, errors.New("mysql: QuerySet: __between needs a 2-element slice")
+		}
+		return quoted + " BETWEEN ? AND ?", values, nil
+	case "isnull":
+		isNull, ok := value.(bool)
+		if !ok {
+			return "", nil, errors.New("mysql: QuerySet: __isnull needs a bool")
+		}
+		if isNull {
+			return quoted + " IS NULL", nil, nil
+		}
+		return quoted + " IS NOT NULL", nil, nil
+	default:
+		return "", nil, errors.New("mysql: QuerySet: unknown operator " + op)
+	}
+}
+
+// likeValue requires value to be a string (like isnull/in/between require
+// their own value shapes) and escapes the LIKE wildcards % and _ in it, so
+// callers' values are matched literally instead of as a pattern.
+func likeValue(op string, value interface{}) (string, error) {
+
+	s, ok := value.(string)
+	if !ok {
+		return "", errors.New("mysql: QuerySet: __" + op + " needs a string")
+	}
+
+	return likeEscaper.Replace(s), nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func toSlice(value interface{}) ([]interface{}, bool) {
+
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		values := make([]interface{}, len(v))
+		for i := range v {
+			values[i] = v[i]
+		}
+		return values, true
+	case []int:
+		values := make([]interface{}, len(v))
+		for i := range v {
+			values[i] = v[i]
+		}
+		return values, true
+	case []int64:
+		values := make([]interface{}, len(v))
+		for i := range v {
+			values[i] = v[i]
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+func (qs *_QuerySet) Filter(colOp string, value interface{}) QuerySet {
+	return qs.addCond(colOp, value, false)
+}
+
+func (qs *_QuerySet) Exclude(colOp string, value interface{}) QuerySet {
+	return qs.addCond(colOp, value, true)
+}
+
+func (qs *_QuerySet) OrderBy(cols ...string) QuerySet {
+	qs.orderBy = cols
+	return qs
+}
+
+func (qs *_QuerySet) GroupBy(cols ...string) QuerySet {
+	qs.groupBy = cols
+	return qs
+}
+
+func (qs *_QuerySet) Limit(n int) QuerySet {
+	qs.limit = n
+	qs.hasLim = true
+	return qs
+}
+
+func (qs *_QuerySet) Offset(n int) QuerySet {
+	qs.offset = n
+	qs.hasOff = true
+	return qs
+}
+
+// whereArgs builds the "WHERE ... GROUP BY ... ORDER BY ... LIMIT ... OFFSET ..."
+// suffix and its positional args.
+func (qs *_QuerySet) whereArgs() (string, []interface{}, error) {
+
+	if qs.err != nil {
+		return "", nil, qs.err
+	}
+
+	var sqlstr string
+	var args []interface{}
+
+	cols := qs.qsColumns()
+
+	if len(qs.conds) > 0 {
+		sqlstr += " WHERE "
+		for i, cond := range qs.conds {
+			if i > 0 {
+				sqlstr += " AND "
+			}
+			sqlstr += cond.sqlstr
+			args = append(args, cond.args...)
+		}
+	}
+
+	if len(qs.groupBy) > 0 {
+		orderCols, err := qsOrderCols(qs.groupBy, cols, false)
+		if err != nil {
+			return "", nil, err
+		}
+		sqlstr += " GROUP BY " + orderCols
+	}
+
+	if len(qs.orderBy) > 0 {
+		orderCols, err := qsOrderCols(qs.orderBy, cols, true)
+		if err != nil {
+			return "", nil, err
+		}
+		sqlstr += " ORDER BY " + orderCols
+	}
+
+	if qs.hasLim {
+		sqlstr += " LIMIT " + strconv.Itoa(qs.limit)
+	}
+
+	if qs.hasOff {
+		sqlstr += " OFFSET " + strconv.Itoa(qs.offset)
+	}
+
+	return sqlstr, args, nil
+}
+
+func qsOrderCols(cols []string, valid map[string]bool, allowDesc bool) (string, error) {
+
+	var out string
+	for i, col := range cols {
+		desc := false
+		if allowDesc && strings.HasPrefix(col, "-") {
+			desc = true
+			col = col[1:]
+		}
+
+		if !valid[col] {
+			return "", errors.New("mysql: QuerySet: unknown column " + col)
+		}
+
+		if i > 0 {
+			out += ", "
+		}
+		out += "`" + col + "`"
+		if desc {
+			out += " DESC"
+		}
+	}
+
+	return out, nil
+}
+
+func (qs *_QuerySet) All(dest interface{}) error {
+
+	extStr, args, err := qs.whereArgs()
+	if err != nil {
+		return err
+	}
+
+	rows, err := qs.db.QueryContext(qs.ctx,
+		"SELECT "+qs.fds.SQLFieldsStr()+" FROM `"+qs.fds.table+"` "+extStr, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanRowsInto(rows, dest)
+}
+
+func (qs *_QuerySet) One(dest interface{}) error {
+
+	qs.Limit(1)
+
+	extStr, args, err := qs.whereArgs()
+	if err != nil {
+		return err
+	}
+
+	row := qs.db.QueryRowContext(qs.ctx,
+		"SELECT "+qs.fds.SQLFieldsStr()+" FROM `"+qs.fds.table+"` "+extStr, args...)
+
+	fieldsMap, err := NewFieldsMap(qs.fds.table, dest)
+	if err != nil {
+		return err
+	}
+
+	if err := row.Scan(fieldsMap.GetFieldAddrs()...); err != nil {
+		return err
+	}
+
+	fieldsMap.MappingBackToObject()
+	return nil
+}
+
+func (qs *_QuerySet) Update(values map[string]interface{}) (sql.Result, error) {
+
+	cols := qs.qsColumns()
+
+	var setStr string
+	var args []interface{}
+	for col, value := range values {
+		if !cols[col] {
+			return nil, errors.New("mysql: QuerySet: unknown column " + col)
+		}
+		if len(setStr) > 0 {
+			setStr += ", "
+		}
+		setStr += "`" + col + "` = ?"
+		args = append(args, value)
+	}
+
+	extStr, whereArgs, err := qs.whereArgs()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, whereArgs...)
+
+	return qs.db.ExecContext(qs.ctx,
+		"UPDATE `"+qs.fds.table+"` SET "+setStr+extStr, args...)
+}
+
+func (qs *_QuerySet) Delete() (sql.Result, error) {
+
+	extStr, args, err := qs.whereArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	return qs.db.ExecContext(qs.ctx, "DELETE FROM `"+qs.fds.table+"` "+extStr, args...)
+}
@@ -0,0 +1,85 @@
+package mysql
+
+import "testing"
+
+func TestQuerySetWhereArgs(t *testing.T) {
+
+	fieldsMap, err := NewFieldsMap(table, &DemoRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	qs := fieldsMap.QuerySet(nil, nil).(*_QuerySet)
+	qs.Filter("field_thr__gte", 10).
+		Exclude("field_one__contains", "x").
+		OrderBy("-field_thr").
+		Limit(5).
+		Offset(10)
+
+	extStr, args, err := qs.whereArgs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantSQL := " WHERE `field_thr` >= ? AND NOT (`field_one` LIKE ?)" +
+		" ORDER BY `field_thr` DESC LIMIT 5 OFFSET 10"
+	if extStr != wantSQL {
+		t.Errorf("extStr = %q, want %q", extStr, wantSQL)
+	}
+
+	if len(args) != 2 || args[0] != 10 || args[1] != "%x%" {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestQuerySetLikeNonString(t *testing.T) {
+
+	fieldsMap, err := NewFieldsMap(table, &DemoRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, op := range []string{"contains", "icontains", "startswith", "endswith", "iexact"} {
+		qs := fieldsMap.QuerySet(nil, nil).(*_QuerySet)
+		qs.Filter("field_one__"+op, 123)
+		if _, _, err := qs.whereArgs(); err == nil {
+			t.Errorf("%s: expected error for non-string value", op)
+		}
+	}
+}
+
+func TestQuerySetLikeEscapesWildcards(t *testing.T) {
+
+	fieldsMap, err := NewFieldsMap(table, &DemoRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	qs := fieldsMap.QuerySet(nil, nil).(*_QuerySet)
+	qs.Filter("field_one__contains", "50%_off")
+
+	_, args, err := qs.whereArgs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `%50\%\_off%`
+	if len(args) != 1 || args[0] != want {
+		t.Errorf("args = %v, want [%q]", args, want)
+	}
+}
+
+func TestQuerySetUnknownColumn(t *testing.T) {
+
+	fieldsMap, err := NewFieldsMap(table, &DemoRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	qs := fieldsMap.QuerySet(nil, nil).(*_QuerySet)
+	qs.Filter("not_a_column", 1)
+
+	if _, _, err := qs.whereArgs(); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
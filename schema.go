@@ -0,0 +1,335 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// Schema generates and applies DDL (CREATE/ALTER/DROP TABLE, CREATE INDEX)
+// from the same `sql:"..."` tags NewFieldsMap reads (see parseSQLTag), so a
+// single struct definition drives both CRUD and schema, similar to xorm's
+// Sync2 and beego's syncdb.
+type Schema struct {
+	// PrintSQL, when true, makes CreateTable/DropTable/Sync2 skip executing
+	// DDL and only populate SQL (Sync2 still reads INFORMATION_SCHEMA).
+	PrintSQL bool
+
+	// SQL holds the CREATE/ALTER/DROP TABLE DDL generated by the most recent
+	// call. The CREATE INDEX statements run after it are not included, and
+	// after a Sync2 over several tables it holds only the last table's DDL.
+	SQL string
+}
+
+// NewSchema new Schema
+func NewSchema() *Schema {
+	return &Schema{}
+}
+
+// CreateTable builds and runs CREATE TABLE for table from objptr's tags
+func (s *Schema) CreateTable(ctx context.Context, db *sql.DB, table string, objptr interface{}) error {
+
+	fds, err := newFieldsMapStruct(table, objptr)
+	if err != nil {
+		return err
+	}
+
+	var cols []string
+	var pks []string
+	var indexes []string
+	for _, field := range fds.fields {
+		cols = append(cols, columnDef(field))
+		if field.PK {
+			pks = append(pks, "`"+field.Tag+"`")
+		}
+		if field.Index {
+			indexes = append(indexes, field.Tag)
+		}
+	}
+
+	sqlstr := "CREATE TABLE `" + table + "` (\n  " + strings.Join(cols, ",\n  ")
+	if len(pks) > 0 {
+		sqlstr += ",\n  PRIMARY KEY (" + strings.Join(pks, ", ") + ")"
+	}
+	sqlstr += "\n)"
+
+	s.SQL = sqlstr
+	if s.PrintSQL {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, sqlstr); err != nil {
+		return err
+	}
+
+	return s.createIndexes(ctx, db, table, indexes)
+}
+
+// DropTable builds and runs DROP TABLE for table
+func (s *Schema) DropTable(ctx context.Context, db *sql.DB, table string) error {
+
+	s.SQL = "DROP TABLE `" + table + "`"
+	if s.PrintSQL {
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, s.SQL)
+	return err
+}
+
+// Sync2 diffs each table (keyed by table name) against its struct's tags,
+// creating the table if it does not exist yet, or otherwise adding any
+// missing columns (with an index for each added column tagged index) and
+// issuing MODIFY COLUMN for any existing column whose type/nullability/default
+// no longer matches the struct. Existing indexes are not inspected, so an
+// index missing on a column that already exists is not created. It never
+// drops a column or index that the struct no longer mentions.
+func (s *Schema) Sync2(ctx context.Context, db *sql.DB, tables map[string]interface{}) error {
+
+	for table, objptr := range tables {
+		if err := s.sync2Table(ctx, db, table, objptr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) sync2Table(ctx context.Context, db *sql.DB, table string, objptr interface{}) error {
+
+	fds, err := newFieldsMapStruct(table, objptr)
+	if err != nil {
+		return err
+	}
+
+	existing, err := existingColumns(ctx, db, table)
+	if err != nil {
+		return err
+	}
+
+	if len(existing) == 0 {
+		return s.CreateTable(ctx, db, table, objptr)
+	}
+
+	var stmts []string
+	var indexes []string
+	for _, field := range fds.fields {
+		col, ok := existing[field.Tag]
+		if !ok {
+			stmts = append(stmts, "ALTER TABLE `"+table+"` ADD COLUMN "+columnDef(field))
+			if field.Index {
+				indexes = append(indexes, field.Tag)
+			}
+			continue
+		}
+		if columnNeedsModify(field, col) {
+			stmts = append(stmts, "ALTER TABLE `"+table+"` MODIFY COLUMN "+columnDef(field))
+		}
+	}
+
+	s.SQL = strings.Join(stmts, ";\n")
+	if s.PrintSQL || len(stmts) == 0 {
+		return nil
+	}
+
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	return s.createIndexes(ctx, db, table, indexes)
+}
+
+func (s *Schema) createIndexes(ctx context.Context, db *sql.DB, table string, cols []string) error {
+
+	for _, col := range cols {
+		idxSQL := "CREATE INDEX `idx_" + table + "_" + col + "` ON `" + table + "` (`" + col + "`)"
+		if _, err := db.ExecContext(ctx, idxSQL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// existingColumn captures one already-present column's attributes, as read
+// from INFORMATION_SCHEMA.COLUMNS, so sync2Table can diff it against the
+// struct field it corresponds to.
+type existingColumn struct {
+	columnType string // e.g. "varchar(64)", "int(10) unsigned"
+	nullable   bool
+	hasDefault bool
+	def        string
+}
+
+// existingColumns lists the columns table already has in the current
+// database along with their type/nullability/default, used to diff against
+// a struct's tags in Sync2.
+func existingColumns(ctx context.Context, db *sql.DB, table string) (map[string]existingColumn, error) {
+
+	rows, err := db.QueryContext(ctx,
+		"SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT FROM INFORMATION_SCHEMA.COLUMNS"+
+			" WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make(map[string]existingColumn)
+	for rows.Next() {
+		var name, columnType, isNullable string
+		var def sql.NullString
+		if err := rows.Scan(&name, &columnType, &isNullable, &def); err != nil {
+			return nil, err
+		}
+		cols[name] = existingColumn{
+			columnType: columnType,
+			nullable:   isNullable == "YES",
+			hasDefault: def.Valid,
+			def:        def.String,
+		}
+	}
+
+	return cols, rows.Err()
+}
+
+// columnNeedsModify reports whether field's expected type, nullability or
+// default no longer matches col, the column's current state in the
+// database, meaning sync2Table must issue a MODIFY COLUMN for it.
+func columnNeedsModify(field Field, col existingColumn) bool {
+
+	if !sameColumnType(sqlColumnType(field), col.columnType) {
+		return true
+	}
+
+	wantNotNull := field.NotNull || field.PK
+	if wantNotNull == col.nullable {
+		return true
+	}
+
+	if field.HasDefault != col.hasDefault {
+		return true
+	}
+	if field.HasDefault && field.Default != col.def {
+		return true
+	}
+
+	return false
+}
+
+// sameColumnType compares a type generated by sqlColumnType against MySQL's
+// INFORMATION_SCHEMA.COLUMNS.COLUMN_TYPE, ignoring case and the integer
+// display width MySQL fills in on its own (e.g. "int(10) unsigned" for
+// "INT UNSIGNED"), so Sync2 doesn't re-issue MODIFY COLUMN forever on a
+// column it already converged on.
+func sameColumnType(want, got string) bool {
+
+	want = strings.ToLower(want)
+	got = strings.ToLower(got)
+	if want == got {
+		return true
+	}
+
+	return stripIntWidth(want) == stripIntWidth(got)
+}
+
+// stripIntWidth drops the "(N)" display width MySQL adds to int-family
+// types, but leaves a bare tinyint's width alone since this package uses
+// TINYINT(1) to mean bool and a width mismatch there is a real type change.
+// TINYINT UNSIGNED (uint8) carries no such meaning in its width, so that one
+// is stripped same as the others.
+func stripIntWidth(columnType string) string {
+
+	open := strings.Index(columnType, "(")
+	if open < 0 {
+		return columnType
+	}
+
+	close := strings.Index(columnType, ")")
+	if close < 0 {
+		return columnType
+	}
+	suffix := columnType[close+1:]
+
+	prefix := columnType[:open]
+	switch prefix {
+	case "smallint", "mediumint", "int", "bigint":
+	case "tinyint":
+		if !strings.Contains(suffix, "unsigned") {
+			return columnType
+		}
+	default:
+		return columnType
+	}
+
+	return prefix + suffix
+}
+
+////////////////////////////////////////////////////////////////
+// DDL generation
+
+// newFieldsMapStruct is NewFieldsMap with the concrete *_FieldsMap exposed,
+// since Schema needs direct access to fds.fields's schema options.
+func newFieldsMapStruct(table string, objptr interface{}) (*_FieldsMap, error) {
+
+	fieldsMap, err := NewFieldsMap(table, objptr)
+	if err != nil {
+		return nil, err
+	}
+
+	return fieldsMap.(*_FieldsMap), nil
+}
+
+// columnDef builds one column's definition for CREATE TABLE/ALTER TABLE
+func columnDef(field Field) string {
+
+	def := "`" + field.Tag + "` " + sqlColumnType(field)
+
+	if field.NotNull || field.PK {
+		def += " NOT NULL"
+	}
+	if field.HasDefault {
+		def += " DEFAULT '" + field.Default + "'"
+	}
+
+	return def
+}
+
+// sqlColumnType maps a Go field type to its MySQL column type
+func sqlColumnType(field Field) string {
+
+	switch field.Type {
+	case "string", "sql.NullString":
+		size := field.Size
+		if size == 0 {
+			size = 255
+		}
+		return "VARCHAR(" + strconv.Itoa(size) + ")"
+	case "int64", "sql.NullInt64":
+		return "BIGINT"
+	case "int", "int32", "int8", "int16":
+		return "INT"
+	case "uint8":
+		return "TINYINT UNSIGNED"
+	case "uint16":
+		return "SMALLINT UNSIGNED"
+	case "uint", "uint32":
+		return "INT UNSIGNED"
+	case "uint64":
+		return "BIGINT UNSIGNED"
+	case "float64", "sql.NullFloat64":
+		return "DOUBLE"
+	case "float32":
+		return "FLOAT"
+	case "bool", "sql.NullBool":
+		return "TINYINT(1)"
+	case "time.Time", "sql.NullTime":
+		return "DATETIME"
+	case "[]uint8":
+		return "BLOB"
+	default:
+		return "VARCHAR(255)"
+	}
+}
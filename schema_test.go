@@ -0,0 +1,130 @@
+package mysql
+
+import "testing"
+
+type SchemaRow struct {
+	FieldKey string `sql:"field_key,pk,size:64"`
+	FieldOne string `sql:"field_one,notnull,default:'x',index"`
+	FieldTwo int64  `sql:"field_two"`
+}
+
+func TestParseSQLTagOptions(t *testing.T) {
+
+	fds, err := newFieldsMapStruct("schema_table", &SchemaRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := fds.fields[0]
+	if key.Tag != "field_key" || !key.PK || key.Size != 64 {
+		t.Errorf("field_key parsed as %+v", key)
+	}
+
+	one := fds.fields[1]
+	if one.Tag != "field_one" || !one.NotNull || !one.Index || !one.HasDefault || one.Default != "x" {
+		t.Errorf("field_one parsed as %+v", one)
+	}
+
+	two := fds.fields[2]
+	if two.Tag != "field_two" || two.PK || two.HasDefault {
+		t.Errorf("field_two parsed as %+v", two)
+	}
+}
+
+func TestColumnNeedsModify(t *testing.T) {
+
+	fds, err := newFieldsMapStruct("schema_table", &SchemaRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, one, two := fds.fields[0], fds.fields[1], fds.fields[2]
+
+	// field_key is VARCHAR(64) NOT NULL: matches an identical column,
+	// even with MySQL's own casing, and needs MODIFY for a width change.
+	if columnNeedsModify(key, existingColumn{columnType: "VARCHAR(64)", nullable: false}) {
+		t.Error("field_key should match an identical existing column")
+	}
+	if !columnNeedsModify(key, existingColumn{columnType: "varchar(32)", nullable: false}) {
+		t.Error("field_key should need MODIFY for a size change")
+	}
+	if !columnNeedsModify(key, existingColumn{columnType: "varchar(64)", nullable: true}) {
+		t.Error("field_key should need MODIFY when the column is nullable")
+	}
+
+	// field_one has a default, so a column with no default (or a
+	// different one) should need MODIFY; an int-family display width
+	// MySQL adds on its own should not trigger a spurious MODIFY.
+	if !columnNeedsModify(one, existingColumn{columnType: "varchar(255)", nullable: false, hasDefault: false}) {
+		t.Error("field_one should need MODIFY when the existing column has no default")
+	}
+	if columnNeedsModify(one, existingColumn{columnType: "varchar(255)", nullable: false, hasDefault: true, def: "x"}) {
+		t.Error("field_one should match an existing column with the same default")
+	}
+	if columnNeedsModify(two, existingColumn{columnType: "bigint(20)", nullable: true}) {
+		t.Error("field_two (BIGINT) should match bigint(20), MySQL's own display width")
+	}
+}
+
+func TestCreateTableDDL(t *testing.T) {
+
+	s := &Schema{PrintSQL: true}
+	if err := s.CreateTable(nil, nil, "schema_table", &SchemaRow{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "CREATE TABLE `schema_table` (\n" +
+		"  `field_key` VARCHAR(64) NOT NULL,\n" +
+		"  `field_one` VARCHAR(255) NOT NULL DEFAULT 'x',\n" +
+		"  `field_two` BIGINT,\n" +
+		"  PRIMARY KEY (`field_key`)\n)"
+	if s.SQL != want {
+		t.Errorf("SQL = %q, want %q", s.SQL, want)
+	}
+}
+
+// UintSchemaRow covers the uint-family DDL: each width must get a MySQL
+// column type that can actually hold its Go range, not all collapsed into
+// INT UNSIGNED (max ~4.29e9, too small for a real uint64).
+type UintSchemaRow struct {
+	FieldSmall uint8  `sql:"field_small"`
+	FieldMed   uint16 `sql:"field_med"`
+	FieldBig   uint64 `sql:"field_big"`
+}
+
+func TestCreateTableDDLUintWidths(t *testing.T) {
+
+	s := &Schema{PrintSQL: true}
+	if err := s.CreateTable(nil, nil, "uint_table", &UintSchemaRow{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "CREATE TABLE `uint_table` (\n" +
+		"  `field_small` TINYINT UNSIGNED,\n" +
+		"  `field_med` SMALLINT UNSIGNED,\n" +
+		"  `field_big` BIGINT UNSIGNED\n)"
+	if s.SQL != want {
+		t.Errorf("SQL = %q, want %q", s.SQL, want)
+	}
+}
+
+func TestColumnNeedsModifyUintWidths(t *testing.T) {
+
+	fds, err := newFieldsMapStruct("uint_table", &UintSchemaRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	small, med, big := fds.fields[0], fds.fields[1], fds.fields[2]
+
+	if columnNeedsModify(small, existingColumn{columnType: "tinyint(3) unsigned", nullable: true}) {
+		t.Error("field_small should match tinyint(3) unsigned, MySQL's own display width")
+	}
+	if columnNeedsModify(med, existingColumn{columnType: "smallint(5) unsigned", nullable: true}) {
+		t.Error("field_med should match smallint(5) unsigned, MySQL's own display width")
+	}
+	if !columnNeedsModify(big, existingColumn{columnType: "int(10) unsigned", nullable: true}) {
+		t.Error("field_big (BIGINT UNSIGNED) should need MODIFY when the column is still INT UNSIGNED")
+	}
+	if columnNeedsModify(big, existingColumn{columnType: "bigint(20) unsigned", nullable: true}) {
+		t.Error("field_big should match bigint(20) unsigned, MySQL's own display width")
+	}
+}